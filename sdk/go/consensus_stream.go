@@ -0,0 +1,229 @@
+package reliquary
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ConsensusEventType identifies the kind of incremental update emitted while
+// a multi-agent consensus request is being processed.
+type ConsensusEventType string
+
+const (
+	ConsensusEventAgentVoted    ConsensusEventType = "agent_voted"
+	ConsensusEventQuorumReached ConsensusEventType = "quorum_reached"
+	ConsensusEventTimedOut      ConsensusEventType = "timed_out"
+	ConsensusEventFinalized     ConsensusEventType = "finalized"
+)
+
+// ConsensusEvent represents a single incremental event delivered over a
+// consensus stream, such as an agent casting a vote or the request reaching
+// a final decision.
+type ConsensusEvent struct {
+	Type            ConsensusEventType     `json:"type"`
+	RequestID       string                 `json:"request_id"`
+	AgentID         string                 `json:"agent_id,omitempty"`
+	Vote            string                 `json:"vote,omitempty"`
+	ConfidenceScore float64                `json:"confidence_score,omitempty"`
+	Result          *ConsensusResult       `json:"result,omitempty"`
+	Metadata        map[string]interface{} `json:"metadata,omitempty"`
+	Timestamp       time.Time              `json:"timestamp"`
+}
+
+// ConsensusEventFilter narrows a StreamConsensusEvents subscription. Zero
+// values are treated as "no filter" for that field.
+type ConsensusEventFilter struct {
+	RequestID    string
+	RequestType  ConsensusType
+	UserID       string
+	ResourcePath string
+	EventTypes   []ConsensusEventType
+}
+
+// SubscribeConsensus opens a long-lived stream of ConsensusEvents for a
+// single consensus request, so callers can observe agent votes and partial
+// confidence scores as they happen instead of polling GetConsensusResult.
+// The returned channel is closed once the request is finalized or ctx is
+// canceled.
+func (c *Client) SubscribeConsensus(ctx context.Context, requestID string) (<-chan ConsensusEvent, error) {
+	return c.StreamConsensusEvents(ctx, ConsensusEventFilter{RequestID: requestID})
+}
+
+// StreamConsensusEvents opens a long-lived connection to /consensus/stream
+// and delivers matching ConsensusEvents as they occur. The connection is
+// backed by Server-Sent Events and automatically reconnects with exponential
+// backoff, resuming from the last event ID it saw. The returned channel is
+// closed when ctx is canceled.
+//
+// This only implements the SSE transport; there is no WebSocket client or
+// fallback negotiation between the two.
+func (c *Client) StreamConsensusEvents(ctx context.Context, filter ConsensusEventFilter) (<-chan ConsensusEvent, error) {
+	events := make(chan ConsensusEvent)
+
+	go c.runConsensusStream(ctx, filter, events)
+
+	return events, nil
+}
+
+// runConsensusStream drives the reconnect loop for a consensus stream until
+// ctx is canceled or the request is finalized.
+func (c *Client) runConsensusStream(ctx context.Context, filter ConsensusEventFilter, events chan<- ConsensusEvent) {
+	defer close(events)
+
+	lastEventID := ""
+	backoff := time.Second
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		finalized, err := c.streamConsensusOnce(ctx, filter, &lastEventID, events)
+		if finalized {
+			return
+		}
+		if err != nil {
+			c.logger.Printf("consensus stream disconnected: %v", err)
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+
+		backoff *= 2
+		if backoff > 30*time.Second {
+			backoff = 30 * time.Second
+		}
+	}
+}
+
+// streamingHTTPClient returns an *http.Client sharing this client's
+// Transport (so TLS/proxy configuration still applies) but with no overall
+// request timeout. http.Client.Timeout bounds the entire exchange including
+// reading the response body, so reusing c.httpClient here would forcibly
+// tear down a long-lived SSE stream every c.timeout; the stream's lifetime
+// should be governed by ctx alone.
+func (c *Client) streamingHTTPClient() *http.Client {
+	return &http.Client{Transport: c.httpClient.Transport}
+}
+
+// streamConsensusOnce performs a single SSE connection attempt, forwarding
+// decoded events until the stream ends, errors, or, when filter names a
+// single RequestID, a Finalized event is observed.
+func (c *Client) streamConsensusOnce(ctx context.Context, filter ConsensusEventFilter, lastEventID *string, events chan<- ConsensusEvent) (finalized bool, err error) {
+	endpoint := c.baseURL + "/consensus/stream" + buildConsensusStreamQuery(filter)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for key, value := range c.getHeaders() {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if *lastEventID != "" {
+		req.Header.Set("Last-Event-ID", *lastEventID)
+	}
+
+	resp, err := c.streamingHTTPClient().Do(req)
+	if err != nil {
+		return false, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return false, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var dataLines []string
+
+	flush := func() error {
+		if len(dataLines) == 0 {
+			return nil
+		}
+		defer func() { dataLines = nil }()
+
+		var event ConsensusEvent
+		if err := json.Unmarshal([]byte(strings.Join(dataLines, "\n")), &event); err != nil {
+			return fmt.Errorf("failed to decode consensus event: %w", err)
+		}
+
+		select {
+		case events <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if event.Type == ConsensusEventFinalized && filter.RequestID != "" {
+			finalized = true
+		}
+
+		return nil
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case line == "":
+			if ferr := flush(); ferr != nil {
+				return finalized, ferr
+			}
+			if finalized {
+				return true, nil
+			}
+		case strings.HasPrefix(line, "id:"):
+			*lastEventID = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+		case strings.HasPrefix(line, "data:"):
+			dataLines = append(dataLines, strings.TrimPrefix(line, "data:"))
+		}
+		// "event:" and other SSE fields are ignored; the event type travels
+		// inside the JSON payload itself.
+	}
+
+	if serr := scanner.Err(); serr != nil {
+		return finalized, fmt.Errorf("stream read error: %w", serr)
+	}
+
+	return finalized, flush()
+}
+
+// buildConsensusStreamQuery turns a ConsensusEventFilter into a URL query
+// string, omitting unset fields.
+func buildConsensusStreamQuery(filter ConsensusEventFilter) string {
+	values := url.Values{}
+	if filter.RequestID != "" {
+		values.Set("request_id", filter.RequestID)
+	}
+	if filter.RequestType != "" {
+		values.Set("request_type", string(filter.RequestType))
+	}
+	if filter.UserID != "" {
+		values.Set("user_id", filter.UserID)
+	}
+	if filter.ResourcePath != "" {
+		values.Set("resource_path", filter.ResourcePath)
+	}
+	for _, eventType := range filter.EventTypes {
+		values.Add("event_type", string(eventType))
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+	return "?" + values.Encode()
+}
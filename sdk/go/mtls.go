@@ -0,0 +1,90 @@
+package reliquary
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+)
+
+// WithTLSConfig sets a custom TLS configuration on the client's underlying
+// HTTP transport. It is most useful for pinning server certificates or
+// setting a non-default ServerName (SNI) when combined with
+// WithClientCertificate.
+//
+// If a TLS configuration is already set (e.g. by WithClientCertificate),
+// tlsConfig is merged into it rather than replacing it wholesale: fields
+// tlsConfig leaves unset (Certificates, RootCAs) are carried over from the
+// existing configuration, so WithTLSConfig can be combined with
+// WithClientCertificate in either order without disabling mTLS.
+func WithTLSConfig(tlsConfig *tls.Config) ClientOption {
+	return func(c *Client) {
+		transport, ok := c.httpClient.Transport.(*http.Transport)
+		if !ok || transport == nil {
+			transport = &http.Transport{}
+		}
+
+		if existing := transport.TLSClientConfig; existing != nil {
+			merged := tlsConfig.Clone()
+			if len(merged.Certificates) == 0 {
+				merged.Certificates = existing.Certificates
+			}
+			if merged.RootCAs == nil {
+				merged.RootCAs = existing.RootCAs
+			}
+			tlsConfig = merged
+		}
+
+		transport.TLSClientConfig = tlsConfig
+		c.httpClient.Transport = transport
+	}
+}
+
+// WithClientCertificate configures the client to authenticate with mutual
+// TLS. certPEM and keyPEM are presented to the server during the TLS
+// handshake, and caPEM, if provided, is used to verify the server's
+// certificate instead of the system trust store. The certificate material is
+// also recorded on the client's credentials so Connect can exchange it for a
+// session token at /auth/cert-login.
+//
+// This option is intended for zero-trust deployments where issuing API keys
+// to agents is undesirable. It merges into any TLS configuration already set
+// by WithTLSConfig (preserving fields like ServerName), so the two options
+// can be applied in either order.
+func WithClientCertificate(certPEM, keyPEM, caPEM []byte) ClientOption {
+	return func(c *Client) {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			c.logger.Printf("failed to load client certificate: %v", err)
+			return
+		}
+
+		transport, ok := c.httpClient.Transport.(*http.Transport)
+		if !ok || transport == nil {
+			transport = &http.Transport{}
+		}
+
+		var tlsConfig *tls.Config
+		if transport.TLSClientConfig != nil {
+			tlsConfig = transport.TLSClientConfig.Clone()
+		} else {
+			tlsConfig = &tls.Config{}
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+
+		if len(caPEM) > 0 {
+			pool := x509.NewCertPool()
+			if pool.AppendCertsFromPEM(caPEM) {
+				tlsConfig.RootCAs = pool
+			} else {
+				c.logger.Printf("failed to parse CA bundle for client certificate")
+			}
+		}
+
+		transport.TLSClientConfig = tlsConfig
+		c.httpClient.Transport = transport
+
+		c.credentials.CertPEM = certPEM
+		c.credentials.KeyPEM = keyPEM
+		c.credentials.CAPEM = caPEM
+	}
+}
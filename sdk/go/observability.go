@@ -0,0 +1,262 @@
+package reliquary
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithTracerProvider instruments every request with an OpenTelemetry span,
+// propagating the W3C traceparent header upstream so server-side spans can
+// be correlated with this client's calls. Without this option, makeRequest
+// records onto a no-op span.
+func WithTracerProvider(tp trace.TracerProvider) ClientOption {
+	return func(c *Client) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider instruments every request with OpenTelemetry metrics: a
+// request latency histogram plus counters for retries, auth failures, and
+// per-endpoint errors.
+func WithMeterProvider(mp metric.MeterProvider) ClientOption {
+	return func(c *Client) {
+		c.meterProvider = mp
+		c.initOtelInstruments()
+	}
+}
+
+// otelInstruments holds the metric instruments created once a
+// MeterProvider is configured. Any instrument that fails to be created is
+// left nil and simply skipped when recording.
+type otelInstruments struct {
+	requestDuration metric.Float64Histogram
+	retries         metric.Int64Counter
+	authFailures    metric.Int64Counter
+	endpointErrors  metric.Int64Counter
+}
+
+func (c *Client) initOtelInstruments() {
+	meter := c.meterProvider.Meter("reliquary-go-sdk")
+
+	requestDuration, err := meter.Float64Histogram(
+		"reliquary.client.request.duration",
+		metric.WithDescription("Duration of ReliQuary API requests"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		c.logger.Printf("failed to create request duration histogram: %v", err)
+	}
+
+	retries, err := meter.Int64Counter(
+		"reliquary.client.request.retries",
+		metric.WithDescription("Number of ReliQuary API request retries"),
+	)
+	if err != nil {
+		c.logger.Printf("failed to create retries counter: %v", err)
+	}
+
+	authFailures, err := meter.Int64Counter(
+		"reliquary.client.auth.failures",
+		metric.WithDescription("Number of ReliQuary authentication failures"),
+	)
+	if err != nil {
+		c.logger.Printf("failed to create auth failures counter: %v", err)
+	}
+
+	endpointErrors, err := meter.Int64Counter(
+		"reliquary.client.endpoint.errors",
+		metric.WithDescription("Number of failed requests, by endpoint"),
+	)
+	if err != nil {
+		c.logger.Printf("failed to create endpoint errors counter: %v", err)
+	}
+
+	c.instruments = &otelInstruments{
+		requestDuration: requestDuration,
+		retries:         retries,
+		authFailures:    authFailures,
+		endpointErrors:  endpointErrors,
+	}
+}
+
+// startSpan starts a span for an API call if a TracerProvider has been
+// configured. Otherwise it returns ctx unchanged and a no-op span, so callers
+// can unconditionally call endSpan without ending a span the caller owns.
+func (c *Client) startSpan(ctx context.Context, method, endpoint string) (context.Context, trace.Span) {
+	if c.tracerProvider == nil {
+		return ctx, trace.SpanFromContext(context.Background())
+	}
+
+	tracer := c.tracerProvider.Tracer("reliquary-go-sdk")
+	return tracer.Start(ctx, method+" "+endpoint,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("reliquary.endpoint", endpoint),
+			attribute.String("http.method", method),
+		),
+	)
+}
+
+// endSpan records the retry count and, if err is non-nil, the error, then
+// ends span.
+func endSpan(span trace.Span, retries int, err error) {
+	span.SetAttributes(attribute.Int("reliquary.retries", retries))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// injectTraceContext propagates the span in ctx onto outgoing request
+// headers as a W3C traceparent header. It is a no-op if no TracerProvider
+// has been configured.
+func (c *Client) injectTraceContext(ctx context.Context, headers map[string]string) {
+	if c.tracerProvider == nil {
+		return
+	}
+	propagation.TraceContext{}.Inject(ctx, propagation.MapCarrier(headers))
+}
+
+// recordRequestMetric records the request latency histogram and, on
+// failure, the per-endpoint error counter. It is a no-op if no
+// MeterProvider has been configured.
+func (c *Client) recordRequestMetric(ctx context.Context, method, endpoint string, duration time.Duration, isError bool) {
+	if c.instruments == nil {
+		return
+	}
+
+	attrs := metric.WithAttributes(
+		attribute.String("reliquary.endpoint", endpoint),
+		attribute.String("http.method", method),
+	)
+
+	if c.instruments.requestDuration != nil {
+		c.instruments.requestDuration.Record(ctx, float64(duration.Milliseconds()), attrs)
+	}
+	if isError && c.instruments.endpointErrors != nil {
+		c.instruments.endpointErrors.Add(ctx, 1, attrs)
+	}
+}
+
+// recordRetry increments the retry counter. It is a no-op if no
+// MeterProvider has been configured.
+func (c *Client) recordRetry(ctx context.Context) {
+	if c.instruments == nil || c.instruments.retries == nil {
+		return
+	}
+	c.instruments.retries.Add(ctx, 1)
+}
+
+// recordAuthFailure increments the auth failure counter. It is a no-op if
+// no MeterProvider has been configured.
+func (c *Client) recordAuthFailure(ctx context.Context) {
+	if c.instruments == nil || c.instruments.authFailures == nil {
+		return
+	}
+	c.instruments.authFailures.Add(ctx, 1)
+}
+
+// recordLocalMetric fans a server-recorded metric out to a local
+// Float64Counter named after it, so a single RecordMetric/TriggerAlert call
+// produces both a server-side metric and a local counter for hybrid
+// dashboards. It is a no-op if no MeterProvider has been configured.
+func (c *Client) recordLocalMetric(ctx context.Context, name string, value float64, labels map[string]string) {
+	if c.meterProvider == nil {
+		return
+	}
+
+	counter := c.customCounter(name)
+	if counter == nil {
+		return
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(labels))
+	for key, val := range labels {
+		attrs = append(attrs, attribute.String(key, val))
+	}
+
+	counter.Add(ctx, value, metric.WithAttributes(attrs...))
+}
+
+// customCounter returns the Float64Counter for a custom metric name,
+// creating and caching it on first use.
+func (c *Client) customCounter(name string) metric.Float64Counter {
+	c.customCountersMu.Lock()
+	defer c.customCountersMu.Unlock()
+
+	if c.customCounters == nil {
+		c.customCounters = make(map[string]metric.Float64Counter)
+	}
+
+	if counter, ok := c.customCounters[name]; ok {
+		return counter
+	}
+
+	meter := c.meterProvider.Meter("reliquary-go-sdk")
+	counter, err := meter.Float64Counter("reliquary.custom." + name)
+	if err != nil {
+		c.logger.Printf("failed to create custom metric counter %q: %v", name, err)
+		return nil
+	}
+
+	c.customCounters[name] = counter
+	return counter
+}
+
+// Prometheus metric descriptors for the client's built-in performance
+// statistics, shared across all Client instances.
+var (
+	promRequestsDesc = prometheus.NewDesc(
+		"reliquary_client_requests_total",
+		"Total number of ReliQuary API requests made by this client.",
+		nil, nil,
+	)
+	promErrorsDesc = prometheus.NewDesc(
+		"reliquary_client_errors_total",
+		"Total number of failed ReliQuary API requests.",
+		nil, nil,
+	)
+	promAvgLatencyDesc = prometheus.NewDesc(
+		"reliquary_client_request_duration_avg_ms",
+		"Average ReliQuary API request latency in milliseconds.",
+		nil, nil,
+	)
+	promAuthenticatedDesc = prometheus.NewDesc(
+		"reliquary_client_authenticated",
+		"Whether the client currently holds a valid access token (1) or not (0).",
+		nil, nil,
+	)
+)
+
+// Describe implements prometheus.Collector, so a Client can be registered
+// directly with prometheus.Register.
+func (c *Client) Describe(ch chan<- *prometheus.Desc) {
+	ch <- promRequestsDesc
+	ch <- promErrorsDesc
+	ch <- promAvgLatencyDesc
+	ch <- promAuthenticatedDesc
+}
+
+// Collect implements prometheus.Collector, exporting the same figures as
+// GetClientStats as scrapable Prometheus metrics.
+func (c *Client) Collect(ch chan<- prometheus.Metric) {
+	stats := c.GetClientStats()
+
+	ch <- prometheus.MustNewConstMetric(promRequestsDesc, prometheus.CounterValue, float64(stats.TotalRequests))
+	ch <- prometheus.MustNewConstMetric(promErrorsDesc, prometheus.CounterValue, float64(stats.ErrorCount))
+	ch <- prometheus.MustNewConstMetric(promAvgLatencyDesc, prometheus.GaugeValue, stats.AverageResponseTimeMs)
+
+	authenticated := 0.0
+	if stats.Authenticated {
+		authenticated = 1.0
+	}
+	ch <- prometheus.MustNewConstMetric(promAuthenticatedDesc, prometheus.GaugeValue, authenticated)
+}
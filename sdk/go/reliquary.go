@@ -15,6 +15,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -23,6 +24,9 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // ConsensusType represents the type of consensus operation
@@ -52,8 +56,26 @@ type AuthCredentials struct {
 	APIKey        string `json:"api_key,omitempty"`
 	AccessToken   string `json:"access_token,omitempty"`
 	DIDPrivateKey string `json:"did_private_key,omitempty"`
+
+	// CertPEM, KeyPEM and CAPEM hold PEM-encoded material for mutual-TLS
+	// authentication. They are populated by WithClientCertificate and are
+	// never marshaled to JSON.
+	CertPEM []byte `json:"-"`
+	KeyPEM  []byte `json:"-"`
+	CAPEM   []byte `json:"-"`
 }
 
+// AuthMethod identifies the mechanism used to establish a session with the
+// ReliQuary platform.
+type AuthMethod string
+
+const (
+	AuthMethodAPIKey      AuthMethod = "api_key"
+	AuthMethodPassword    AuthMethod = "password"
+	AuthMethodCertificate AuthMethod = "certificate"
+	AuthMethodDID         AuthMethod = "did"
+)
+
 // ConsensusRequest represents a multi-agent consensus request
 type ConsensusRequest struct {
 	RequestType    ConsensusType          `json:"request_type"`
@@ -118,8 +140,24 @@ type Client struct {
 	httpClient  *http.Client
 	logger      *log.Logger
 
-	accessToken  string
-	tokenExpires *time.Time
+	tokenSource   TokenSource
+	tokenSkew     time.Duration
+	tokenMu       sync.RWMutex
+	token         *Token
+	refresherStop chan struct{}
+	refresherDone chan struct{}
+
+	zkBackends       []Backend
+	verificationKeys *VerificationKeyCache
+
+	tracerProvider   trace.TracerProvider
+	meterProvider    metric.MeterProvider
+	instruments      *otelInstruments
+	customCounters   map[string]metric.Float64Counter
+	customCountersMu sync.Mutex
+
+	breaker     *circuitBreaker
+	rateLimiter *rateLimiter
 
 	// Performance tracking
 	mu                sync.RWMutex
@@ -166,6 +204,7 @@ func NewClient(baseURL string, credentials *AuthCredentials, options ...ClientOp
 		maxRetries:  3,
 		httpClient:  &http.Client{Timeout: 30 * time.Second},
 		logger:      log.New(io.Discard, "", 0), // Default to no logging
+		tokenSkew:   60 * time.Second,
 	}
 
 	// Apply options
@@ -173,10 +212,6 @@ func NewClient(baseURL string, credentials *AuthCredentials, options ...ClientOp
 		option(client)
 	}
 
-	if client.credentials.AccessToken != "" {
-		client.accessToken = client.credentials.AccessToken
-	}
-
 	return client
 }
 
@@ -194,63 +229,30 @@ func NewClientWithCredentials(baseURL, username, password string, options ...Cli
 
 // Connect establishes connection and authenticates with the ReliQuary platform
 func (c *Client) Connect(ctx context.Context) error {
-	if c.accessToken == "" {
-		if err := c.authenticate(ctx); err != nil {
-			return fmt.Errorf("authentication failed: %w", err)
-		}
+	if c.tokenSource == nil {
+		c.tokenSource = c.defaultTokenSource()
 	}
-	c.logger.Println("Connected to ReliQuary platform")
-	return nil
-}
 
-// Disconnect closes the connection to the ReliQuary platform
-func (c *Client) Disconnect() {
-	c.accessToken = ""
-	c.tokenExpires = nil
-	c.logger.Println("Disconnected from ReliQuary platform")
-}
-
-// authenticate performs authentication with the ReliQuary platform
-func (c *Client) authenticate(ctx context.Context) error {
-	if c.credentials.APIKey != "" {
-		// API key authentication
-		c.accessToken = c.credentials.APIKey
-		return nil
+	if _, err := c.refreshToken(ctx); err != nil {
+		return fmt.Errorf("authentication failed: %w", err)
 	}
 
-	if c.credentials.Username != "" && c.credentials.Password != "" {
-		// Username/password authentication
-		authData := map[string]string{
-			"username": c.credentials.Username,
-			"password": c.credentials.Password,
-		}
-
-		var response map[string]interface{}
-		if err := c.makeRequest(ctx, "POST", "/auth/login", authData, &response); err != nil {
-			return err
-		}
-
-		accessToken, ok := response["access_token"].(string)
-		if !ok {
-			return fmt.Errorf("invalid access token in response")
-		}
-		c.accessToken = accessToken
+	c.startRefresher()
 
-		// Calculate token expiration
-		if expiresIn, ok := response["expires_in"].(float64); ok {
-			expiry := time.Now().Add(time.Duration(expiresIn) * time.Second)
-			c.tokenExpires = &expiry
-		}
+	c.logger.Println("Connected to ReliQuary platform")
+	return nil
+}
 
-		return nil
-	}
+// Disconnect stops the background token refresher and closes the connection
+// to the ReliQuary platform.
+func (c *Client) Disconnect() {
+	c.stopRefresher()
 
-	if c.credentials.DIDPrivateKey != "" {
-		// DID-based authentication
-		return fmt.Errorf("DID authentication not yet implemented")
-	}
+	c.tokenMu.Lock()
+	c.token = nil
+	c.tokenMu.Unlock()
 
-	return fmt.Errorf("no valid authentication credentials provided")
+	c.logger.Println("Disconnected from ReliQuary platform")
 }
 
 // getHeaders returns the request headers with authentication
@@ -260,8 +262,12 @@ func (c *Client) getHeaders() map[string]string {
 		"User-Agent":   "ReliQuary-Go-SDK/1.0.0",
 	}
 
-	if c.accessToken != "" {
-		headers["Authorization"] = "Bearer " + c.accessToken
+	c.tokenMu.RLock()
+	token := c.token
+	c.tokenMu.RUnlock()
+
+	if token != nil && token.AccessToken != "" {
+		headers["Authorization"] = "Bearer " + token.AccessToken
 	}
 
 	return headers
@@ -269,34 +275,75 @@ func (c *Client) getHeaders() map[string]string {
 
 // makeRequest makes an authenticated API request with retries
 func (c *Client) makeRequest(ctx context.Context, method, endpoint string, data interface{}, result interface{}) error {
+	ctx, span := c.startSpan(ctx, method, endpoint)
+
 	url := c.baseURL + endpoint
+	retries := 0
 
 	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if c.breaker != nil {
+			if !c.breaker.allow() {
+				endSpan(span, retries, ErrCircuitOpen)
+				return ErrCircuitOpen
+			}
+			// Guarantees the half-open probe slot allow() just granted is
+			// freed by the time makeRequest returns, even if this attempt
+			// aborts (e.g. the rate limiter wait below hits ctx.Done())
+			// before performRequest's recordSuccess/recordFailure call
+			// would otherwise have cleared it.
+			defer c.breaker.release()
+		}
+
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.wait(ctx, endpoint); err != nil {
+				endSpan(span, retries, err)
+				return err
+			}
+		}
+
 		startTime := time.Now()
 
 		if err := c.performRequest(ctx, method, url, data, result); err != nil {
 			responseTime := time.Since(startTime)
 			c.updateStats(responseTime, true)
+			c.recordRequestMetric(ctx, method, endpoint, responseTime, true)
+			if c.breaker != nil {
+				c.breaker.recordFailure()
+			}
+
+			var statusErr *httpStatusError
+			if c.rateLimiter != nil && errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusTooManyRequests {
+				c.rateLimiter.tighten(endpoint, parseRetryAfter(statusErr.Header))
+			}
 
-			// Check for 401 and retry authentication
-			if strings.Contains(err.Error(), "401") && attempt == 0 {
-				if authErr := c.authenticate(ctx); authErr != nil {
+			// Check for 401 and force a token refresh
+			if strings.Contains(err.Error(), "401") && attempt == 0 && c.tokenSource != nil {
+				c.recordAuthFailure(ctx)
+				if _, authErr := c.refreshToken(ctx); authErr != nil {
+					endSpan(span, retries, authErr)
 					return fmt.Errorf("re-authentication failed: %w", authErr)
 				}
+				retries++
+				c.recordRetry(ctx)
 				continue
 			}
 
 			c.logger.Printf("Request attempt %d failed: %v", attempt+1, err)
 
 			if attempt == c.maxRetries {
+				endSpan(span, retries, err)
 				return err
 			}
 
+			retries++
+			c.recordRetry(ctx)
+
 			// Exponential backoff
 			backoff := time.Duration(1<<uint(attempt)) * time.Second
 			select {
 			case <-time.After(backoff):
 			case <-ctx.Done():
+				endSpan(span, retries, ctx.Err())
 				return ctx.Err()
 			}
 			continue
@@ -304,11 +351,18 @@ func (c *Client) makeRequest(ctx context.Context, method, endpoint string, data
 
 		responseTime := time.Since(startTime)
 		c.updateStats(responseTime, false)
+		c.recordRequestMetric(ctx, method, endpoint, responseTime, false)
+		if c.breaker != nil {
+			c.breaker.recordSuccess()
+		}
 		c.logger.Printf("%s %s -> Success (%v)", method, endpoint, responseTime)
+		endSpan(span, retries, nil)
 		return nil
 	}
 
-	return fmt.Errorf("all retry attempts failed")
+	err := fmt.Errorf("all retry attempts failed")
+	endSpan(span, retries, err)
+	return err
 }
 
 // performRequest performs a single HTTP request
@@ -328,7 +382,9 @@ func (c *Client) performRequest(ctx context.Context, method, url string, data in
 	}
 
 	// Add headers
-	for key, value := range c.getHeaders() {
+	headers := c.getHeaders()
+	c.injectTraceContext(ctx, headers)
+	for key, value := range headers {
 		req.Header.Set(key, value)
 	}
 
@@ -340,7 +396,7 @@ func (c *Client) performRequest(ctx context.Context, method, url string, data in
 
 	if resp.StatusCode >= 400 {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(bodyBytes))
+		return &httpStatusError{StatusCode: resp.StatusCode, Header: resp.Header, Body: string(bodyBytes)}
 	}
 
 	if result != nil {
@@ -382,20 +438,16 @@ func (c *Client) GetSystemStatus(ctx context.Context) (map[string]interface{}, e
 
 // Authentication Methods
 
-// RefreshToken refreshes the authentication token
+// RefreshToken forces an immediate refresh of the authentication token via
+// the client's TokenSource and returns the new access token. Long-running
+// services do not need to call this directly: Connect starts a background
+// goroutine that refreshes proactively as the token nears expiry.
 func (c *Client) RefreshToken(ctx context.Context) (string, error) {
-	var response map[string]interface{}
-	if err := c.makeRequest(ctx, "POST", "/auth/refresh", nil, &response); err != nil {
+	token, err := c.refreshToken(ctx)
+	if err != nil {
 		return "", err
 	}
-
-	accessToken, ok := response["access_token"].(string)
-	if !ok {
-		return "", fmt.Errorf("invalid access token in response")
-	}
-
-	c.accessToken = accessToken
-	return accessToken, nil
+	return token.AccessToken, nil
 }
 
 // GetUserProfile gets the current user profile
@@ -523,7 +575,10 @@ func (c *Client) GetCrossChainStatus(ctx context.Context, transactionID string)
 
 // Observability Methods
 
-// RecordMetric records a custom metric
+// RecordMetric records a custom metric. If a MeterProvider has been
+// configured via WithMeterProvider, the same value is also recorded to a
+// local Float64Counter named after metric, so a single call produces both a
+// server-side metric and a locally scrapable one.
 func (c *Client) RecordMetric(ctx context.Context, name string, value float64, labels map[string]string) (map[string]interface{}, error) {
 	if labels == nil {
 		labels = make(map[string]string)
@@ -537,6 +592,7 @@ func (c *Client) RecordMetric(ctx context.Context, name string, value float64, l
 
 	var result map[string]interface{}
 	err := c.makeRequest(ctx, "POST", "/observability/metrics/record", requestData, &result)
+	c.recordLocalMetric(ctx, name, value, labels)
 	return result, err
 }
 
@@ -547,7 +603,8 @@ func (c *Client) GetSystemDashboard(ctx context.Context) (map[string]interface{}
 	return result, err
 }
 
-// TriggerAlert manually triggers an alert
+// TriggerAlert manually triggers an alert. Like RecordMetric, it also fans
+// the value out to the local MeterProvider, if one has been configured.
 func (c *Client) TriggerAlert(ctx context.Context, metricName string, value float64, description string) (map[string]interface{}, error) {
 	if description == "" {
 		description = "SDK triggered alert"
@@ -561,6 +618,7 @@ func (c *Client) TriggerAlert(ctx context.Context, metricName string, value floa
 
 	var result map[string]interface{}
 	err := c.makeRequest(ctx, "POST", "/observability/alerts/trigger", requestData, &result)
+	c.recordLocalMetric(ctx, metricName, value, map[string]string{"alert": "true"})
 	return result, err
 }
 
@@ -609,13 +667,17 @@ func (c *Client) GetClientStats() *ClientStats {
 		errorRate = float64(c.errorCount) / float64(c.requestCount) * 100
 	}
 
+	c.tokenMu.RLock()
+	authenticated := c.token != nil && c.token.AccessToken != ""
+	c.tokenMu.RUnlock()
+
 	return &ClientStats{
 		TotalRequests:         c.requestCount,
 		AverageResponseTimeMs: avgResponseTime,
 		ErrorCount:            c.errorCount,
 		ErrorRatePercent:      errorRate,
 		BaseURL:               c.baseURL,
-		Authenticated:         c.accessToken != "",
+		Authenticated:         authenticated,
 	}
 }
 
@@ -0,0 +1,343 @@
+package reliquary
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by makeRequest when a configured circuit
+// breaker is open, so the request is rejected locally instead of hitting a
+// struggling server.
+var ErrCircuitOpen = fmt.Errorf("reliquary: circuit breaker is open")
+
+// httpStatusError carries the HTTP status code and response headers for a
+// non-2xx response, so callers like the rate limiter can react to specific
+// statuses (e.g. 429 with a Retry-After header) without re-parsing the error
+// string. Its Error() text is unchanged from before this type existed, so
+// existing string-matching callers keep working.
+type httpStatusError struct {
+	StatusCode int
+	Header     http.Header
+	Body       string
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+// parseRetryAfter reads a Retry-After header, which the HTTP spec allows to
+// be either a number of seconds or an HTTP date, returning zero if the
+// header is absent or unparseable.
+func parseRetryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		return time.Until(when)
+	}
+	return 0
+}
+
+// CircuitBreakerState is the operating state of a CircuitBreaker.
+type CircuitBreakerState int
+
+const (
+	CircuitClosed CircuitBreakerState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+func (s CircuitBreakerState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerConfig configures the circuit breaker installed by
+// WithCircuitBreaker.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that trips the
+	// breaker from closed to open. Defaults to 5 if zero.
+	FailureThreshold int
+	// CooldownPeriod is how long the breaker stays open before allowing a
+	// single half-open probe request through. Defaults to 30s if zero.
+	CooldownPeriod time.Duration
+	// HalfOpenSuccesses is the number of consecutive successful half-open
+	// probes required to close the breaker again. Defaults to 1 if zero.
+	HalfOpenSuccesses int
+}
+
+// WithCircuitBreaker guards every request with a closed/open/half-open
+// circuit breaker, so a struggling backend doesn't get hit by a thundering
+// herd of simultaneous retries. When the breaker is open, makeRequest
+// returns ErrCircuitOpen immediately instead of contacting the server.
+func WithCircuitBreaker(config CircuitBreakerConfig) ClientOption {
+	return func(c *Client) {
+		c.breaker = newCircuitBreaker(config)
+	}
+}
+
+// circuitBreaker is a simple closed/open/half-open breaker guarding
+// makeRequest against cascading failures.
+type circuitBreaker struct {
+	config CircuitBreakerConfig
+
+	mu              sync.Mutex
+	state           CircuitBreakerState
+	consecutiveFail int
+	consecutiveOK   int
+	openedAt        time.Time
+	probeInFlight   bool
+}
+
+func newCircuitBreaker(config CircuitBreakerConfig) *circuitBreaker {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = 5
+	}
+	if config.CooldownPeriod <= 0 {
+		config.CooldownPeriod = 30 * time.Second
+	}
+	if config.HalfOpenSuccesses <= 0 {
+		config.HalfOpenSuccesses = 1
+	}
+	return &circuitBreaker{config: config, state: CircuitClosed}
+}
+
+// allow reports whether a request may proceed, transitioning the breaker
+// from open to half-open once the cooldown period has elapsed. While
+// half-open, only a single in-flight probe is admitted at a time; concurrent
+// callers are rejected until that probe's outcome is recorded, so a
+// struggling backend doesn't get hit with a fresh thundering herd the moment
+// the cooldown expires.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitOpen {
+		if time.Since(b.openedAt) < b.config.CooldownPeriod {
+			return false
+		}
+		b.state = CircuitHalfOpen
+		b.consecutiveOK = 0
+		b.probeInFlight = false
+	}
+
+	if b.state == CircuitHalfOpen {
+		if b.probeInFlight {
+			return false
+		}
+		b.probeInFlight = true
+	}
+
+	return true
+}
+
+// release clears a half-open probe slot without recording an outcome. It is
+// safe to call unconditionally after allow() has granted a request, even if
+// recordSuccess/recordFailure already ran for that same request (those clear
+// probeInFlight themselves, so this is then a no-op) or never runs at all,
+// e.g. because the caller's context was canceled before the request reached
+// the backend. Without this, a half-open probe admitted by allow() but
+// abandoned before its outcome is observed would leave probeInFlight stuck
+// true forever, rejecting every subsequent call even once the backend has
+// recovered.
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitHalfOpen {
+		b.probeInFlight = false
+	}
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitHalfOpen {
+		b.probeInFlight = false
+		b.consecutiveOK++
+		if b.consecutiveOK >= b.config.HalfOpenSuccesses {
+			b.state = CircuitClosed
+			b.consecutiveFail = 0
+		}
+		return
+	}
+
+	b.consecutiveFail = 0
+}
+
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitHalfOpen {
+		b.probeInFlight = false
+		b.state = CircuitOpen
+		b.openedAt = time.Now()
+		return
+	}
+
+	b.consecutiveFail++
+	if b.consecutiveFail >= b.config.FailureThreshold {
+		b.state = CircuitOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// State returns the breaker's current state.
+func (b *circuitBreaker) State() CircuitBreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// WithRateLimit caps outgoing requests with a token-bucket limiter, keyed
+// per endpoint pattern so a burst against one endpoint (e.g. consensus
+// voting) doesn't starve others. rps is the steady-state request rate and
+// burst the bucket's capacity.
+func WithRateLimit(rps, burst int) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter = newRateLimiter(float64(rps), burst)
+	}
+}
+
+// rateLimiter holds one token bucket per endpoint pattern.
+type rateLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	rps     float64
+	burst   int
+}
+
+func newRateLimiter(rps float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		buckets: make(map[string]*tokenBucket),
+		rps:     rps,
+		burst:   burst,
+	}
+}
+
+// rateLimitPattern reduces an endpoint like "/consensus/result/abc123" to
+// "/consensus/result", so requests to the same logical operation share a
+// bucket regardless of path parameters.
+func rateLimitPattern(endpoint string) string {
+	path := endpoint
+	if idx := strings.IndexByte(path, '?'); idx >= 0 {
+		path = path[:idx]
+	}
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) > 2 {
+		parts = parts[:2]
+	}
+
+	return "/" + strings.Join(parts, "/")
+}
+
+func (r *rateLimiter) bucketFor(endpoint string) *tokenBucket {
+	pattern := rateLimitPattern(endpoint)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	bucket, ok := r.buckets[pattern]
+	if !ok {
+		bucket = newTokenBucket(r.rps, r.burst)
+		r.buckets[pattern] = bucket
+	}
+	return bucket
+}
+
+// wait blocks until a token is available for endpoint's pattern, or ctx is
+// canceled.
+func (r *rateLimiter) wait(ctx context.Context, endpoint string) error {
+	return r.bucketFor(endpoint).wait(ctx)
+}
+
+// tighten reduces the local rate for endpoint's pattern to match a
+// server-provided Retry-After, e.g. after a 429 response. It never raises
+// the rate above the configured default.
+func (r *rateLimiter) tighten(endpoint string, retryAfter time.Duration) {
+	if retryAfter <= 0 {
+		return
+	}
+
+	tightened := 1 / retryAfter.Seconds()
+	if tightened < r.rps {
+		r.bucketFor(endpoint).setRate(tightened)
+	}
+}
+
+// tokenBucket is a minimal token-bucket rate limiter.
+type tokenBucket struct {
+	mu         sync.Mutex
+	rate       float64 // tokens per second
+	burst      float64
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newTokenBucket(rps float64, burst int) *tokenBucket {
+	if burst <= 0 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:       rps,
+		burst:      float64(burst),
+		tokens:     float64(burst),
+		lastRefill: time.Now(),
+	}
+}
+
+func (tb *tokenBucket) wait(ctx context.Context) error {
+	for {
+		tb.mu.Lock()
+		now := time.Now()
+		tb.tokens += now.Sub(tb.lastRefill).Seconds() * tb.rate
+		if tb.tokens > tb.burst {
+			tb.tokens = tb.burst
+		}
+		tb.lastRefill = now
+
+		if tb.tokens >= 1 {
+			tb.tokens--
+			tb.mu.Unlock()
+			return nil
+		}
+
+		deficit := 1 - tb.tokens
+		rate := tb.rate
+		tb.mu.Unlock()
+
+		wait := time.Duration(deficit / rate * float64(time.Second))
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (tb *tokenBucket) setRate(rps float64) {
+	tb.mu.Lock()
+	tb.rate = rps
+	tb.mu.Unlock()
+}
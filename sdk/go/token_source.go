@@ -0,0 +1,280 @@
+package reliquary
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Token is an access token together with its expiration, mirroring the
+// shape consumers of oauth2.TokenSource will already be familiar with.
+type Token struct {
+	AccessToken string
+	Expiry      time.Time // zero value means the token does not expire
+}
+
+// TokenSource supplies access tokens for authenticating requests, similar in
+// spirit to oauth2.TokenSource. Implementations are responsible for
+// obtaining, and where applicable refreshing, the underlying token; the
+// client calls Token whenever it needs a fresh one and caches the result.
+type TokenSource interface {
+	// Token returns a currently valid access token, performing a network
+	// round-trip if necessary.
+	Token(ctx context.Context) (*Token, error)
+}
+
+// WithTokenSource overrides how the client obtains and refreshes access
+// tokens, e.g. to plug in an OIDC/JWT provider. When set, the Username,
+// Password, APIKey, CertPEM/KeyPEM and DIDPrivateKey fields on
+// AuthCredentials are ignored.
+func WithTokenSource(source TokenSource) ClientOption {
+	return func(c *Client) {
+		c.tokenSource = source
+	}
+}
+
+// WithTokenRefreshSkew sets how far ahead of a token's expiry the background
+// refresher proactively renews it. The default is 60 seconds.
+func WithTokenRefreshSkew(skew time.Duration) ClientOption {
+	return func(c *Client) {
+		c.tokenSkew = skew
+	}
+}
+
+// refreshToken fetches a new token from the client's TokenSource and caches
+// it, returning the cached copy.
+func (c *Client) refreshToken(ctx context.Context) (*Token, error) {
+	token, err := c.tokenSource.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.tokenMu.Lock()
+	c.token = token
+	c.tokenMu.Unlock()
+
+	return token, nil
+}
+
+// startRefresher launches the background goroutine that keeps the cached
+// token fresh. It is a no-op if a refresher is already running.
+func (c *Client) startRefresher() {
+	if c.refresherStop != nil {
+		return
+	}
+
+	c.refresherStop = make(chan struct{})
+	c.refresherDone = make(chan struct{})
+
+	stop := c.refresherStop
+	done := c.refresherDone
+
+	go func() {
+		defer close(done)
+
+		const maxRefreshBackoff = 5 * time.Minute
+		consecutiveFailures := 0
+
+		for {
+			c.tokenMu.RLock()
+			token := c.token
+			c.tokenMu.RUnlock()
+
+			wait := c.tokenSkew
+			if token != nil && !token.Expiry.IsZero() {
+				if until := time.Until(token.Expiry.Add(-c.tokenSkew)); until > 0 {
+					wait = until
+				} else {
+					wait = 0
+				}
+			} else if token != nil {
+				// Token never expires; nothing to do until Disconnect.
+				<-stop
+				return
+			}
+
+			// A failed refresh doesn't change the (possibly already-expired)
+			// token, so without this the loop above would keep computing
+			// wait == 0 and hammer the auth endpoint in a tight busy loop
+			// for as long as the outage lasts. Back off exponentially on
+			// consecutive failures instead, capped at maxRefreshBackoff.
+			if consecutiveFailures > 0 {
+				backoff := c.tokenSkew * time.Duration(1<<uint(consecutiveFailures-1))
+				if backoff <= 0 || backoff > maxRefreshBackoff {
+					backoff = maxRefreshBackoff
+				}
+				if backoff > wait {
+					wait = backoff
+				}
+			}
+
+			select {
+			case <-time.After(wait):
+			case <-stop:
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+			_, err := c.refreshToken(ctx)
+			cancel()
+
+			if err != nil {
+				consecutiveFailures++
+				c.logger.Printf("background token refresh failed (%d consecutive): %v", consecutiveFailures, err)
+				continue
+			}
+			consecutiveFailures = 0
+		}
+	}()
+}
+
+// stopRefresher stops the background refresher goroutine started by
+// startRefresher, if any, and waits for it to exit.
+func (c *Client) stopRefresher() {
+	if c.refresherStop == nil {
+		return
+	}
+
+	close(c.refresherStop)
+	<-c.refresherDone
+
+	c.refresherStop = nil
+	c.refresherDone = nil
+}
+
+// defaultTokenSource builds the TokenSource implied by the client's
+// AuthCredentials, with the precedence bare access token > API key >
+// username/password > client certificate > DID. A pre-set AccessToken wins,
+// as it always has: NewClient used to install it directly. If none of those are set, it returns a source that fails on first
+// use rather than silently authenticating with an empty token.
+func (c *Client) defaultTokenSource() TokenSource {
+	creds := c.credentials
+
+	switch {
+	case creds.AccessToken != "":
+		return staticTokenSource{&Token{AccessToken: creds.AccessToken}}
+	case creds.APIKey != "":
+		return staticTokenSource{&Token{AccessToken: creds.APIKey}}
+	case creds.Username != "" && creds.Password != "":
+		return &passwordTokenSource{client: c, username: creds.Username, password: creds.Password}
+	case len(creds.CertPEM) > 0 && len(creds.KeyPEM) > 0:
+		return &certTokenSource{client: c}
+	case creds.DIDPrivateKey != "":
+		return didTokenSource{}
+	default:
+		return errTokenSource{fmt.Errorf("no valid authentication credentials provided")}
+	}
+}
+
+// staticTokenSource always returns the same token and never refreshes. It
+// backs API key authentication and a pre-obtained AccessToken.
+type staticTokenSource struct {
+	token *Token
+}
+
+func (s staticTokenSource) Token(ctx context.Context) (*Token, error) {
+	return s.token, nil
+}
+
+// errTokenSource always fails with the same error. It backs
+// defaultTokenSource's fallback case so an unconfigured client fails fast on
+// Connect instead of authenticating with a silently empty token.
+type errTokenSource struct {
+	err error
+}
+
+func (s errTokenSource) Token(ctx context.Context) (*Token, error) {
+	return nil, s.err
+}
+
+// passwordTokenSource authenticates against /auth/login with a username and
+// password, re-authenticating from scratch on every call.
+type passwordTokenSource struct {
+	client   *Client
+	username string
+	password string
+}
+
+func (s *passwordTokenSource) Token(ctx context.Context) (*Token, error) {
+	authData := map[string]string{
+		"username": s.username,
+		"password": s.password,
+	}
+
+	var response map[string]interface{}
+	if err := s.client.makeRequest(ctx, "POST", "/auth/login", authData, &response); err != nil {
+		return nil, err
+	}
+
+	return tokenFromAuthResponse(response)
+}
+
+// certTokenSource exchanges the client certificate presented at the TLS
+// layer (via WithClientCertificate) for a session token.
+type certTokenSource struct {
+	client *Client
+}
+
+func (s *certTokenSource) Token(ctx context.Context) (*Token, error) {
+	var response map[string]interface{}
+	if err := s.client.makeRequest(ctx, "POST", "/auth/cert-login", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return tokenFromAuthResponse(response)
+}
+
+// didTokenSource is a placeholder for DID-based authentication, which is not
+// yet implemented.
+type didTokenSource struct{}
+
+func (didTokenSource) Token(ctx context.Context) (*Token, error) {
+	return nil, fmt.Errorf("DID authentication not yet implemented")
+}
+
+// refreshTokenSource exchanges a refresh token for a new access token via
+// /auth/refresh, rotating the refresh token when the server returns one.
+type refreshTokenSource struct {
+	client       *Client
+	refreshToken string
+}
+
+// NewRefreshTokenSource returns a TokenSource that calls /auth/refresh with
+// the given refresh token to obtain access tokens, rotating the refresh
+// token itself whenever the server returns a new one.
+func NewRefreshTokenSource(client *Client, refreshToken string) TokenSource {
+	return &refreshTokenSource{client: client, refreshToken: refreshToken}
+}
+
+func (s *refreshTokenSource) Token(ctx context.Context) (*Token, error) {
+	requestData := map[string]string{
+		"refresh_token": s.refreshToken,
+	}
+
+	var response map[string]interface{}
+	if err := s.client.makeRequest(ctx, "POST", "/auth/refresh", requestData, &response); err != nil {
+		return nil, err
+	}
+
+	if newRefreshToken, ok := response["refresh_token"].(string); ok && newRefreshToken != "" {
+		s.refreshToken = newRefreshToken
+	}
+
+	return tokenFromAuthResponse(response)
+}
+
+// tokenFromAuthResponse extracts an access token and optional expiry from a
+// decoded /auth/* JSON response.
+func tokenFromAuthResponse(response map[string]interface{}) (*Token, error) {
+	accessToken, ok := response["access_token"].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid access token in response")
+	}
+
+	token := &Token{AccessToken: accessToken}
+	if expiresIn, ok := response["expires_in"].(float64); ok {
+		token.Expiry = time.Now().Add(time.Duration(expiresIn) * time.Second)
+	}
+
+	return token, nil
+}
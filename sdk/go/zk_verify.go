@@ -0,0 +1,597 @@
+package reliquary
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// Backend verifies zero-knowledge proofs for a specific proving system and
+// curve entirely client-side, without contacting the ReliQuary server.
+type Backend interface {
+	// Name identifies the backend, e.g. "groth16-bn254" or "plonk-bls12-381".
+	// VerifyZKProofLocal selects a backend by matching Name against the
+	// proof's declared "protocol" and "curve" fields.
+	Name() string
+
+	// Verify checks proof against publicSignals using the verification key
+	// vk, returning false (not an error) for a proof that simply fails to
+	// verify.
+	Verify(proof map[string]interface{}, publicSignals []string, vk map[string]interface{}) (bool, error)
+}
+
+// WithZKBackend registers an additional local ZK proof verification
+// backend. Backends registered this way are tried before the built-in
+// Groth16 backends, so callers can override or extend proof system support.
+func WithZKBackend(backend Backend) ClientOption {
+	return func(c *Client) {
+		c.zkBackends = append(c.zkBackends, backend)
+	}
+}
+
+// zkBackendFor returns the first registered or built-in backend whose Name
+// matches the proof/protocol and curve declared in proof.
+func (c *Client) zkBackendFor(proof map[string]interface{}) (Backend, error) {
+	protocol, _ := proof["protocol"].(string)
+	curve, _ := proof["curve"].(string)
+	name := zkBackendName(protocol, curve)
+
+	for _, backend := range c.zkBackends {
+		if backend.Name() == name {
+			return backend, nil
+		}
+	}
+
+	for _, backend := range builtinZKBackends {
+		if backend.Name() == name {
+			return backend, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no local ZK backend registered for protocol %q on curve %q", protocol, curve)
+}
+
+func zkBackendName(protocol, curve string) string {
+	return fmt.Sprintf("%s-%s", protocol, normalizeZKCurve(curve))
+}
+
+func normalizeZKCurve(curve string) string {
+	switch curve {
+	case "bn128", "bn254", "altbn128":
+		return "bn254"
+	case "bls12-381", "bls12381":
+		return "bls12-381"
+	default:
+		return curve
+	}
+}
+
+var builtinZKBackends = []Backend{
+	groth16Backend{curve: "bn254"},
+	groth16Backend{curve: "bls12-381"},
+}
+
+// groth16Backend verifies Groth16 proofs using gnark-crypto's pairing
+// engine for the final pairing check:
+//
+//	e(A, B) == e(Alpha, Beta) * e(IC(publicSignals), Gamma) * e(C, Delta)
+//
+// It understands the snarkjs-style JSON encoding (decimal-string affine
+// coordinates) that GenerateZKProof's server-side counterpart emits.
+type groth16Backend struct {
+	curve string // "bn254" or "bls12-381"
+}
+
+func (b groth16Backend) Name() string {
+	return zkBackendName("groth16", b.curve)
+}
+
+func (b groth16Backend) Verify(proof map[string]interface{}, publicSignals []string, vk map[string]interface{}) (bool, error) {
+	switch b.curve {
+	case "bn254":
+		return verifyGroth16BN254(proof, publicSignals, vk)
+	case "bls12-381":
+		return verifyGroth16BLS12381(proof, publicSignals, vk)
+	default:
+		return false, fmt.Errorf("unsupported curve %q for groth16 verification", b.curve)
+	}
+}
+
+func verifyGroth16BN254(proof map[string]interface{}, publicSignals []string, vk map[string]interface{}) (bool, error) {
+	a, err := parseBN254G1(proof["pi_a"])
+	if err != nil {
+		return false, fmt.Errorf("invalid pi_a: %w", err)
+	}
+	b, err := parseBN254G2(proof["pi_b"])
+	if err != nil {
+		return false, fmt.Errorf("invalid pi_b: %w", err)
+	}
+	c, err := parseBN254G1(proof["pi_c"])
+	if err != nil {
+		return false, fmt.Errorf("invalid pi_c: %w", err)
+	}
+
+	alpha, err := parseBN254G1(vk["vk_alpha_1"])
+	if err != nil {
+		return false, fmt.Errorf("invalid vk_alpha_1: %w", err)
+	}
+	beta, err := parseBN254G2(vk["vk_beta_2"])
+	if err != nil {
+		return false, fmt.Errorf("invalid vk_beta_2: %w", err)
+	}
+	gamma, err := parseBN254G2(vk["vk_gamma_2"])
+	if err != nil {
+		return false, fmt.Errorf("invalid vk_gamma_2: %w", err)
+	}
+	delta, err := parseBN254G2(vk["vk_delta_2"])
+	if err != nil {
+		return false, fmt.Errorf("invalid vk_delta_2: %w", err)
+	}
+
+	icRaw, ok := vk["IC"].([]interface{})
+	if !ok || len(icRaw) == 0 {
+		return false, fmt.Errorf("vk.IC is missing or empty")
+	}
+	ic := make([]bn254.G1Affine, len(icRaw))
+	for i, point := range icRaw {
+		p, err := parseBN254G1(point)
+		if err != nil {
+			return false, fmt.Errorf("invalid IC[%d]: %w", i, err)
+		}
+		ic[i] = p
+	}
+	if len(publicSignals) != len(ic)-1 {
+		return false, fmt.Errorf("expected %d public signals, got %d", len(ic)-1, len(publicSignals))
+	}
+
+	vkX := ic[0]
+	for i, signal := range publicSignals {
+		value, ok := new(big.Int).SetString(signal, 10)
+		if !ok {
+			return false, fmt.Errorf("public signal %d is not a base-10 integer", i)
+		}
+		var term bn254.G1Affine
+		term.ScalarMultiplication(&ic[i+1], value)
+
+		var sum bn254.G1Jac
+		sum.FromAffine(&vkX)
+		var termJac bn254.G1Jac
+		termJac.FromAffine(&term)
+		sum.AddAssign(&termJac)
+		vkX.FromJacobian(&sum)
+	}
+
+	// e(A, B) == e(Alpha, Beta) * e(vkX, Gamma) * e(C, Delta)
+	// is checked as a single multi-pairing with the right-hand side negated:
+	// e(A, B) * e(-Alpha, Beta) * e(-vkX, Gamma) * e(-C, Delta) == 1
+	negAlpha := alpha
+	negAlpha.Neg(&alpha)
+	negVKX := vkX
+	negVKX.Neg(&vkX)
+	negC := c
+	negC.Neg(&c)
+
+	result, err := bn254.PairingCheck(
+		[]bn254.G1Affine{a, negAlpha, negVKX, negC},
+		[]bn254.G2Affine{b, beta, gamma, delta},
+	)
+	if err != nil {
+		return false, fmt.Errorf("pairing check failed: %w", err)
+	}
+
+	return result, nil
+}
+
+// verifyGroth16BLS12381 mirrors verifyGroth16BN254 for the BLS12-381 curve.
+func verifyGroth16BLS12381(proof map[string]interface{}, publicSignals []string, vk map[string]interface{}) (bool, error) {
+	a, err := parseBLS12381G1(proof["pi_a"])
+	if err != nil {
+		return false, fmt.Errorf("invalid pi_a: %w", err)
+	}
+	b, err := parseBLS12381G2(proof["pi_b"])
+	if err != nil {
+		return false, fmt.Errorf("invalid pi_b: %w", err)
+	}
+	c, err := parseBLS12381G1(proof["pi_c"])
+	if err != nil {
+		return false, fmt.Errorf("invalid pi_c: %w", err)
+	}
+
+	alpha, err := parseBLS12381G1(vk["vk_alpha_1"])
+	if err != nil {
+		return false, fmt.Errorf("invalid vk_alpha_1: %w", err)
+	}
+	beta, err := parseBLS12381G2(vk["vk_beta_2"])
+	if err != nil {
+		return false, fmt.Errorf("invalid vk_beta_2: %w", err)
+	}
+	gamma, err := parseBLS12381G2(vk["vk_gamma_2"])
+	if err != nil {
+		return false, fmt.Errorf("invalid vk_gamma_2: %w", err)
+	}
+	delta, err := parseBLS12381G2(vk["vk_delta_2"])
+	if err != nil {
+		return false, fmt.Errorf("invalid vk_delta_2: %w", err)
+	}
+
+	icRaw, ok := vk["IC"].([]interface{})
+	if !ok || len(icRaw) == 0 {
+		return false, fmt.Errorf("vk.IC is missing or empty")
+	}
+	ic := make([]bls12381.G1Affine, len(icRaw))
+	for i, point := range icRaw {
+		p, err := parseBLS12381G1(point)
+		if err != nil {
+			return false, fmt.Errorf("invalid IC[%d]: %w", i, err)
+		}
+		ic[i] = p
+	}
+	if len(publicSignals) != len(ic)-1 {
+		return false, fmt.Errorf("expected %d public signals, got %d", len(ic)-1, len(publicSignals))
+	}
+
+	vkX := ic[0]
+	for i, signal := range publicSignals {
+		value, ok := new(big.Int).SetString(signal, 10)
+		if !ok {
+			return false, fmt.Errorf("public signal %d is not a base-10 integer", i)
+		}
+		var term bls12381.G1Affine
+		term.ScalarMultiplication(&ic[i+1], value)
+
+		var sum bls12381.G1Jac
+		sum.FromAffine(&vkX)
+		var termJac bls12381.G1Jac
+		termJac.FromAffine(&term)
+		sum.AddAssign(&termJac)
+		vkX.FromJacobian(&sum)
+	}
+
+	negAlpha := alpha
+	negAlpha.Neg(&alpha)
+	negVKX := vkX
+	negVKX.Neg(&vkX)
+	negC := c
+	negC.Neg(&c)
+
+	result, err := bls12381.PairingCheck(
+		[]bls12381.G1Affine{a, negAlpha, negVKX, negC},
+		[]bls12381.G2Affine{b, beta, gamma, delta},
+	)
+	if err != nil {
+		return false, fmt.Errorf("pairing check failed: %w", err)
+	}
+
+	return result, nil
+}
+
+// parseBN254G1 reads a snarkjs-style ["x", "y", "1"] projective point,
+// dropping the trailing 1, into an affine bn254.G1Affine point.
+func parseBN254G1(raw interface{}) (bn254.G1Affine, error) {
+	var p bn254.G1Affine
+
+	coords, ok := raw.([]interface{})
+	if !ok || len(coords) < 2 {
+		return p, fmt.Errorf("expected a 2- or 3-element coordinate array")
+	}
+
+	x, ok := coords[0].(string)
+	if !ok {
+		return p, fmt.Errorf("x coordinate must be a string")
+	}
+	y, ok := coords[1].(string)
+	if !ok {
+		return p, fmt.Errorf("y coordinate must be a string")
+	}
+
+	xBig, ok := new(big.Int).SetString(x, 10)
+	if !ok {
+		return p, fmt.Errorf("x coordinate is not a base-10 integer")
+	}
+	yBig, ok := new(big.Int).SetString(y, 10)
+	if !ok {
+		return p, fmt.Errorf("y coordinate is not a base-10 integer")
+	}
+
+	p.X.SetBigInt(xBig)
+	p.Y.SetBigInt(yBig)
+
+	// PairingCheck does not itself validate its inputs, so an
+	// attacker-controlled proof could otherwise smuggle in an invalid-curve
+	// or small-subgroup point and force a spurious "valid" result.
+	if !p.IsOnCurve() {
+		return p, fmt.Errorf("point is not on curve")
+	}
+	if !p.IsInSubGroup() {
+		return p, fmt.Errorf("point is not in the correct subgroup")
+	}
+
+	return p, nil
+}
+
+// parseBN254G2 reads a snarkjs-style [[x0,x1],[y0,y1],[1,0]] point into an
+// affine bn254.G2Affine point.
+func parseBN254G2(raw interface{}) (bn254.G2Affine, error) {
+	var p bn254.G2Affine
+
+	coords, ok := raw.([]interface{})
+	if !ok || len(coords) < 2 {
+		return p, fmt.Errorf("expected a 2- or 3-element coordinate array")
+	}
+
+	x, err := parseBN254Fp2(coords[0])
+	if err != nil {
+		return p, fmt.Errorf("x: %w", err)
+	}
+	y, err := parseBN254Fp2(coords[1])
+	if err != nil {
+		return p, fmt.Errorf("y: %w", err)
+	}
+
+	p.X = x
+	p.Y = y
+
+	if !p.IsOnCurve() {
+		return p, fmt.Errorf("point is not on curve")
+	}
+	if !p.IsInSubGroup() {
+		return p, fmt.Errorf("point is not in the correct subgroup")
+	}
+
+	return p, nil
+}
+
+func parseBN254Fp2(raw interface{}) (bn254.E2, error) {
+	var e bn254.E2
+
+	parts, ok := raw.([]interface{})
+	if !ok || len(parts) != 2 {
+		return e, fmt.Errorf("expected a 2-element array")
+	}
+
+	a0, ok := parts[0].(string)
+	if !ok {
+		return e, fmt.Errorf("component 0 must be a string")
+	}
+	a1, ok := parts[1].(string)
+	if !ok {
+		return e, fmt.Errorf("component 1 must be a string")
+	}
+
+	a0Big, ok := new(big.Int).SetString(a0, 10)
+	if !ok {
+		return e, fmt.Errorf("component 0 is not a base-10 integer")
+	}
+	a1Big, ok := new(big.Int).SetString(a1, 10)
+	if !ok {
+		return e, fmt.Errorf("component 1 is not a base-10 integer")
+	}
+
+	e.A0.SetBigInt(a0Big)
+	e.A1.SetBigInt(a1Big)
+
+	return e, nil
+}
+
+// parseBLS12381G1 mirrors parseBN254G1 for the BLS12-381 curve.
+func parseBLS12381G1(raw interface{}) (bls12381.G1Affine, error) {
+	var p bls12381.G1Affine
+
+	coords, ok := raw.([]interface{})
+	if !ok || len(coords) < 2 {
+		return p, fmt.Errorf("expected a 2- or 3-element coordinate array")
+	}
+
+	x, ok := coords[0].(string)
+	if !ok {
+		return p, fmt.Errorf("x coordinate must be a string")
+	}
+	y, ok := coords[1].(string)
+	if !ok {
+		return p, fmt.Errorf("y coordinate must be a string")
+	}
+
+	xBig, ok := new(big.Int).SetString(x, 10)
+	if !ok {
+		return p, fmt.Errorf("x coordinate is not a base-10 integer")
+	}
+	yBig, ok := new(big.Int).SetString(y, 10)
+	if !ok {
+		return p, fmt.Errorf("y coordinate is not a base-10 integer")
+	}
+
+	p.X.SetBigInt(xBig)
+	p.Y.SetBigInt(yBig)
+
+	if !p.IsOnCurve() {
+		return p, fmt.Errorf("point is not on curve")
+	}
+	if !p.IsInSubGroup() {
+		return p, fmt.Errorf("point is not in the correct subgroup")
+	}
+
+	return p, nil
+}
+
+// parseBLS12381G2 mirrors parseBN254G2 for the BLS12-381 curve.
+func parseBLS12381G2(raw interface{}) (bls12381.G2Affine, error) {
+	var p bls12381.G2Affine
+
+	coords, ok := raw.([]interface{})
+	if !ok || len(coords) < 2 {
+		return p, fmt.Errorf("expected a 2- or 3-element coordinate array")
+	}
+
+	x, err := parseBLS12381Fp2(coords[0])
+	if err != nil {
+		return p, fmt.Errorf("x: %w", err)
+	}
+	y, err := parseBLS12381Fp2(coords[1])
+	if err != nil {
+		return p, fmt.Errorf("y: %w", err)
+	}
+
+	p.X = x
+	p.Y = y
+
+	if !p.IsOnCurve() {
+		return p, fmt.Errorf("point is not on curve")
+	}
+	if !p.IsInSubGroup() {
+		return p, fmt.Errorf("point is not in the correct subgroup")
+	}
+
+	return p, nil
+}
+
+func parseBLS12381Fp2(raw interface{}) (bls12381.E2, error) {
+	var e bls12381.E2
+
+	parts, ok := raw.([]interface{})
+	if !ok || len(parts) != 2 {
+		return e, fmt.Errorf("expected a 2-element array")
+	}
+
+	a0, ok := parts[0].(string)
+	if !ok {
+		return e, fmt.Errorf("component 0 must be a string")
+	}
+	a1, ok := parts[1].(string)
+	if !ok {
+		return e, fmt.Errorf("component 1 must be a string")
+	}
+
+	a0Big, ok := new(big.Int).SetString(a0, 10)
+	if !ok {
+		return e, fmt.Errorf("component 0 is not a base-10 integer")
+	}
+	a1Big, ok := new(big.Int).SetString(a1, 10)
+	if !ok {
+		return e, fmt.Errorf("component 1 is not a base-10 integer")
+	}
+
+	e.A0.SetBigInt(a0Big)
+	e.A1.SetBigInt(a1Big)
+
+	return e, nil
+}
+
+// VerificationKeyCache fetches and caches verification keys from
+// /zk/circuits/{type}/vk so VerifyZKProofLocal doesn't re-fetch one on every
+// call.
+type VerificationKeyCache struct {
+	client *Client
+
+	mu   sync.RWMutex
+	keys map[string]map[string]interface{}
+}
+
+// NewVerificationKeyCache creates a VerificationKeyCache backed by client.
+func NewVerificationKeyCache(client *Client) *VerificationKeyCache {
+	return &VerificationKeyCache{
+		client: client,
+		keys:   make(map[string]map[string]interface{}),
+	}
+}
+
+// Get returns the verification key for circuitType, fetching and caching it
+// from the server on first use.
+func (vc *VerificationKeyCache) Get(ctx context.Context, circuitType string) (map[string]interface{}, error) {
+	vc.mu.RLock()
+	vk, ok := vc.keys[circuitType]
+	vc.mu.RUnlock()
+	if ok {
+		return vk, nil
+	}
+
+	var response map[string]interface{}
+	if err := vc.client.makeRequest(ctx, "GET", "/zk/circuits/"+circuitType+"/vk", nil, &response); err != nil {
+		return nil, err
+	}
+
+	vc.mu.Lock()
+	vc.keys[circuitType] = response
+	vc.mu.Unlock()
+
+	return response, nil
+}
+
+// Invalidate drops the cached verification key for circuitType, forcing the
+// next Get to re-fetch it from the server.
+func (vc *VerificationKeyCache) Invalidate(circuitType string) {
+	vc.mu.Lock()
+	delete(vc.keys, circuitType)
+	vc.mu.Unlock()
+}
+
+// TrustedSetup pins a circuit's verification key to a known-good fingerprint
+// so callers can detect VK tampering or a server serving a different circuit
+// than expected.
+type TrustedSetup struct {
+	CircuitType string
+	Fingerprint string // hex-encoded SHA-256 of the VK's canonical JSON encoding
+}
+
+// FingerprintVerificationKey computes the SHA-256 fingerprint of a
+// verification key using its canonical (key-sorted) JSON encoding.
+func FingerprintVerificationKey(vk map[string]interface{}) (string, error) {
+	canonical, err := json.Marshal(vk)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode verification key: %w", err)
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Verify reports whether vk's fingerprint matches t.Fingerprint.
+func (t TrustedSetup) Verify(vk map[string]interface{}) (bool, error) {
+	fingerprint, err := FingerprintVerificationKey(vk)
+	if err != nil {
+		return false, err
+	}
+	return fingerprint == t.Fingerprint, nil
+}
+
+// vkCache lazily creates the client's VerificationKeyCache.
+func (c *Client) vkCache() *VerificationKeyCache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.verificationKeys == nil {
+		c.verificationKeys = NewVerificationKeyCache(c)
+	}
+	return c.verificationKeys
+}
+
+// VerifyZKProofLocal verifies a zero-knowledge proof entirely client-side,
+// without contacting the ReliQuary server. If vk is nil, the verification
+// key is fetched (and cached) from /zk/circuits/{circuitType}/vk. Use
+// WithZKBackend to support proof systems or curves beyond the built-in
+// Groth16 verifiers for bn254 and bls12-381.
+//
+// PLONK proofs are not yet supported: builtinZKBackends only registers
+// Groth16, so a PLONK proof fails with "no local ZK backend registered"
+// unless a caller supplies its own Backend via WithZKBackend.
+func (c *Client) VerifyZKProofLocal(ctx context.Context, circuitType string, proof map[string]interface{}, publicSignals []string, vk map[string]interface{}) (bool, error) {
+	if vk == nil {
+		fetched, err := c.vkCache().Get(ctx, circuitType)
+		if err != nil {
+			return false, fmt.Errorf("failed to fetch verification key: %w", err)
+		}
+		vk = fetched
+	}
+
+	backend, err := c.zkBackendFor(proof)
+	if err != nil {
+		return false, err
+	}
+
+	return backend.Verify(proof, publicSignals, vk)
+}
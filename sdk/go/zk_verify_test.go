@@ -0,0 +1,207 @@
+package reliquary
+
+import "testing"
+
+// TestBuiltinZKBackendNamesMatchLookup guards against the backend name and
+// the lookup key computed by zkBackendFor drifting apart again: a backend
+// that registers under a name VerifyZKProofLocal can never look up is
+// silently useless.
+func TestBuiltinZKBackendNamesMatchLookup(t *testing.T) {
+	proof := map[string]interface{}{
+		"protocol": "groth16",
+		"curve":    "bn128", // snarkjs spelling; normalizeZKCurve maps this to "bn254"
+	}
+
+	want := zkBackendName(proof["protocol"].(string), proof["curve"].(string))
+
+	found := false
+	for _, backend := range builtinZKBackends {
+		if backend.Name() == want {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		names := make([]string, len(builtinZKBackends))
+		for i, backend := range builtinZKBackends {
+			names[i] = backend.Name()
+		}
+		t.Fatalf("no builtin ZK backend registered under %q (have %v)", want, names)
+	}
+}
+
+// bn254Groth16Fixture is a self-consistent (not snarkjs-derived) Groth16
+// instance: pi_b, vk_beta_2, vk_gamma_2 and vk_delta_2 all equal a single G2
+// point b, and pi_a is constructed as alpha + vkX + pi_c in G1,
+// so that
+//
+//	e(pi_a, b) == e(alpha, b) * e(vkX, b) * e(pi_c, b)
+//
+// holds by bilinearity regardless of b's discrete log — exactly the relation
+// verifyGroth16BN254 checks. The coordinates below were computed once with
+// gnark-crypto (scalar multiples 7, 3, 5, 11 of the standard generators,
+// public signal 13) and pinned here as a golden vector; they don't depend on
+// an actual circuit or trusted setup.
+func bn254Groth16Fixture() (proof, vk map[string]interface{}, publicSignals []string) {
+	alpha := []interface{}{"10415861484417082502655338383609494480414113902179649885744799961447382638712", "10196215078179488638353184030336251401353352596818396260819493263908881608606", "1"}
+	ic0 := []interface{}{"3353031288059533942658390886683067124040920775575537747144343083137631628272", "19321533766552368860946552437480515441416830039777911637913418824951667761761", "1"}
+	ic1 := []interface{}{"10744596414106452074759370245733544594153395043370666422502510773307029471145", "848677436511517736191562425154572367705380862894644942948681172815252343932", "1"}
+	c := []interface{}{"19033251874843656108471242320417533909414939332036131356573128480367742634479", "20792135454608030201903199625673964159744755218442260092768620403349374102584", "1"}
+	a := []interface{}{"7142838843266529001604018722827887156656779262266685940020376112524965488225", "9847400003132061842422320880235213676846426757887412532314970990569880727048", "1"}
+	b := []interface{}{
+		[]interface{}{"10857046999023057135944570762232829481370756359578518086990519993285655852781", "11559732032986387107991004021392285783925812861821192530917403151452391805634"},
+		[]interface{}{"8495653923123431417604973247489272438418190587263600148770280649306958101930", "4082367875863433681332203403145435568316851327593401208105741076214120093531"},
+		[]interface{}{"1", "0"},
+	}
+
+	proof = map[string]interface{}{
+		"protocol": "groth16",
+		"curve":    "bn254",
+		"pi_a":     a,
+		"pi_b":     b,
+		"pi_c":     c,
+	}
+	vk = map[string]interface{}{
+		"vk_alpha_1": alpha,
+		"vk_beta_2":  b,
+		"vk_gamma_2": b,
+		"vk_delta_2": b,
+		"IC":         []interface{}{ic0, ic1},
+	}
+	publicSignals = []string{"13"}
+	return proof, vk, publicSignals
+}
+
+func TestVerifyGroth16BN254(t *testing.T) {
+	t.Run("valid proof verifies", func(t *testing.T) {
+		proof, vk, publicSignals := bn254Groth16Fixture()
+
+		ok, err := verifyGroth16BN254(proof, publicSignals, vk)
+		if err != nil {
+			t.Fatalf("verifyGroth16BN254 returned an error for a valid proof: %v", err)
+		}
+		if !ok {
+			t.Fatal("verifyGroth16BN254 returned false for a valid proof")
+		}
+	})
+
+	t.Run("wrong public signal is rejected", func(t *testing.T) {
+		proof, vk, _ := bn254Groth16Fixture()
+
+		ok, err := verifyGroth16BN254(proof, []string{"14"}, vk)
+		if err != nil {
+			t.Fatalf("verifyGroth16BN254 errored instead of rejecting a wrong public signal: %v", err)
+		}
+		if ok {
+			t.Fatal("verifyGroth16BN254 accepted a proof against the wrong public signal")
+		}
+	})
+
+	t.Run("tampered proof point is rejected", func(t *testing.T) {
+		proof, vk, publicSignals := bn254Groth16Fixture()
+		// Swap in vk_alpha_1 for pi_a: still a valid, on-curve, in-subgroup
+		// point, but not the one the relation was built from.
+		proof["pi_a"] = vk["vk_alpha_1"]
+
+		ok, err := verifyGroth16BN254(proof, publicSignals, vk)
+		if err != nil {
+			t.Fatalf("verifyGroth16BN254 errored instead of rejecting a tampered proof: %v", err)
+		}
+		if ok {
+			t.Fatal("verifyGroth16BN254 accepted a tampered proof")
+		}
+	})
+
+	t.Run("off-curve point is rejected at parse time", func(t *testing.T) {
+		proof, vk, publicSignals := bn254Groth16Fixture()
+		proof["pi_a"] = []interface{}{"1", "1", "1"} // does not satisfy y^2 = x^3 + 3
+
+		_, err := verifyGroth16BN254(proof, publicSignals, vk)
+		if err == nil {
+			t.Fatal("verifyGroth16BN254 did not reject an off-curve pi_a")
+		}
+	})
+}
+
+// bls12381Groth16Fixture mirrors bn254Groth16Fixture for BLS12-381, using
+// the same scalar multiples (7, 3, 5, 11; public signal 13) of that curve's
+// generators.
+func bls12381Groth16Fixture() (proof, vk map[string]interface{}, publicSignals []string) {
+	alpha := []interface{}{"3872473689207892378470335395114902631176541028916158626161662840934315241539439160301564344905260612642783644023991", "2547806390474846378491145127515427451279430889101277169890334737406180277792171092197824251632631671609860505999900", "1"}
+	ic0 := []interface{}{"1527649530533633684281386512094328299672026648504329745640827351945739272160755686119065091946435084697047221031460", "487897572011753812113448064805964756454529228648704488481988876974355015977479905373670519228592356747638779818193", "1"}
+	ic1 := []interface{}{"2601793266141653880357945339922727723793268013331457916525213050197274797722760296318099993752923714935161798464476", "3498096627312022583321348410616510759186251088555060790999813363211667535344132702692445545590448314959259020805858", "1"}
+	c := []interface{}{"152387348683924138328143764814868516652582147878375891005399726039073598211013784035034571365338571582701764549205", "665105738604193407187869466118276726708407579576722424320519765435543092874091633788813503861572804644225114385040", "1"}
+	a := []interface{}{"3921910835919697321054532925617748984743702546592497234747863823347237965057591544417795387699385185830483197530706", "427130501172438345307999458955939168076399288697791119289783085371814954404057193876426957380658097857850213138517", "1"}
+	b := []interface{}{
+		[]interface{}{"352701069587466618187139116011060144890029952792775240219908644239793785735715026873347600343865175952761926303160", "3059144344244213709971259814753781636986470325476647558659373206291635324768958432433509563104347017837885763365758"},
+		[]interface{}{"1985150602287291935568054521177171638300868978215655730859378665066344726373823718423869104263333984641494340347905", "927553665492332455747201965776037880757740193453592970025027978793976877002675564980949289727957565575433344219582"},
+		[]interface{}{"1", "0"},
+	}
+
+	proof = map[string]interface{}{
+		"protocol": "groth16",
+		"curve":    "bls12-381",
+		"pi_a":     a,
+		"pi_b":     b,
+		"pi_c":     c,
+	}
+	vk = map[string]interface{}{
+		"vk_alpha_1": alpha,
+		"vk_beta_2":  b,
+		"vk_gamma_2": b,
+		"vk_delta_2": b,
+		"IC":         []interface{}{ic0, ic1},
+	}
+	publicSignals = []string{"13"}
+	return proof, vk, publicSignals
+}
+
+func TestVerifyGroth16BLS12381(t *testing.T) {
+	t.Run("valid proof verifies", func(t *testing.T) {
+		proof, vk, publicSignals := bls12381Groth16Fixture()
+
+		ok, err := verifyGroth16BLS12381(proof, publicSignals, vk)
+		if err != nil {
+			t.Fatalf("verifyGroth16BLS12381 returned an error for a valid proof: %v", err)
+		}
+		if !ok {
+			t.Fatal("verifyGroth16BLS12381 returned false for a valid proof")
+		}
+	})
+
+	t.Run("wrong public signal is rejected", func(t *testing.T) {
+		proof, vk, _ := bls12381Groth16Fixture()
+
+		ok, err := verifyGroth16BLS12381(proof, []string{"14"}, vk)
+		if err != nil {
+			t.Fatalf("verifyGroth16BLS12381 errored instead of rejecting a wrong public signal: %v", err)
+		}
+		if ok {
+			t.Fatal("verifyGroth16BLS12381 accepted a proof against the wrong public signal")
+		}
+	})
+
+	t.Run("tampered proof point is rejected", func(t *testing.T) {
+		proof, vk, publicSignals := bls12381Groth16Fixture()
+		proof["pi_a"] = vk["vk_alpha_1"]
+
+		ok, err := verifyGroth16BLS12381(proof, publicSignals, vk)
+		if err != nil {
+			t.Fatalf("verifyGroth16BLS12381 errored instead of rejecting a tampered proof: %v", err)
+		}
+		if ok {
+			t.Fatal("verifyGroth16BLS12381 accepted a tampered proof")
+		}
+	})
+
+	t.Run("off-curve point is rejected at parse time", func(t *testing.T) {
+		proof, vk, publicSignals := bls12381Groth16Fixture()
+		proof["pi_a"] = []interface{}{"1", "1", "1"} // does not satisfy the BLS12-381 curve equation
+
+		_, err := verifyGroth16BLS12381(proof, publicSignals, vk)
+		if err == nil {
+			t.Fatal("verifyGroth16BLS12381 did not reject an off-curve pi_a")
+		}
+	})
+}